@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// tfPlanStates is the subset of `terraform show -json <planfile>` that
+// exposes the two state snapshots a plan is computed from: prior_state is
+// the state after refresh, prev_run_state is the state as of the end of the
+// last apply. Anything that differs between the two happened outside of
+// terraform, i.e. drift.
+type tfPlanStates struct {
+	PriorState   *tfPlanState `json:"prior_state"`
+	PrevRunState *tfPlanState `json:"prev_run_state"`
+}
+
+type tfPlanState struct {
+	Values *tfPlanStateValues `json:"values"`
+}
+
+type tfPlanStateValues struct {
+	RootModule tfPlanStateModule `json:"root_module"`
+}
+
+type tfPlanStateModule struct {
+	Resources    []tfPlanStateResource `json:"resources"`
+	ChildModules []tfPlanStateModule   `json:"child_modules"`
+}
+
+type tfPlanStateResource struct {
+	Address string                 `json:"address"`
+	Values  map[string]interface{} `json:"values"`
+}
+
+// flattenResources walks child modules to return every resource in a state,
+// since `terraform show -json` nests resources by module.
+func flattenResources(m tfPlanStateModule) []tfPlanStateResource {
+	resources := append([]tfPlanStateResource{}, m.Resources...)
+	for _, child := range m.ChildModules {
+		resources = append(resources, flattenResources(child)...)
+	}
+	return resources
+}
+
+// detectDrift compares prev_run_state against prior_state in the plan JSON
+// document planJSON and returns the addresses of resources whose attributes
+// differ between the two, sorted for stable output. A resource that exists
+// in prior_state but not prev_run_state (e.g. newly imported) is not
+// considered drift.
+func detectDrift(planJSON []byte) ([]string, error) {
+	var plan tfPlanStates
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan json for drift detection: %w", err)
+	}
+
+	if plan.PriorState == nil || plan.PriorState.Values == nil ||
+		plan.PrevRunState == nil || plan.PrevRunState.Values == nil {
+		return nil, nil
+	}
+
+	prevValuesByAddress := make(map[string]map[string]interface{})
+	for _, r := range flattenResources(plan.PrevRunState.Values.RootModule) {
+		prevValuesByAddress[r.Address] = r.Values
+	}
+
+	var drifted []string
+	for _, r := range flattenResources(plan.PriorState.Values.RootModule) {
+		prevValues, ok := prevValuesByAddress[r.Address]
+		if !ok {
+			continue
+		}
+		if !reflect.DeepEqual(prevValues, r.Values) {
+			drifted = append(drifted, r.Address)
+		}
+	}
+
+	sort.Strings(drifted)
+
+	return drifted, nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/overmindtech/cli/tracing"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tfcRunURLRe picks the run ID out of the "To view this run in a browser,
+// visit: https://app.terraform.io/app/<org>/<workspace>/runs/run-xxxx" line
+// that `terraform plan` prints when the working directory is backed by
+// Terraform Cloud/Enterprise.
+var tfcRunURLRe = regexp.MustCompile(`https://[^/\s]+/app/[^/\s]+/[^/\s]+/runs/(run-[A-Za-z0-9]+)`)
+
+// remoteBackendRunModel stands in for runPlanModel when the working
+// directory's backend is Terraform Cloud/Enterprise. `terraform plan` still
+// runs locally - that's what queues the remote run in the first place - but
+// it never produces a local plan file, so this parses the run ID out of its
+// output, polls the run via the TFC API, and downloads the canonical plan
+// JSON once it's ready. The result can then be handed to the existing
+// submitPlanModel exactly like a local plan file would be.
+type remoteBackendRunModel struct {
+	taskModel
+
+	ctx       context.Context
+	planArgs  []string
+	token     string
+	workspace string
+
+	runID    string
+	planJSON []byte
+}
+
+// remoteRunQueuedMsg is sent once `terraform plan` has queued a TFC run.
+type remoteRunQueuedMsg struct {
+	runID string
+}
+
+// remoteRunPlannedMsg is sent once the TFC run has finished planning and its
+// plan JSON has been downloaded.
+type remoteRunPlannedMsg struct {
+	planJSON []byte
+}
+
+// NewRemoteBackendRunModel returns a model that runs `terraform plan` with
+// planArgs and follows the resulting Terraform Cloud run through to a
+// downloaded plan JSON.
+func NewRemoteBackendRunModel(planArgs []string, workspace string) remoteBackendRunModel {
+	token := viper.GetString("tfc-token")
+	if token == "" {
+		token = os.Getenv("TFC_TOKEN")
+	}
+
+	return remoteBackendRunModel{
+		taskModel: NewTaskModel("Running remote `terraform plan`"),
+		planArgs:  planArgs,
+		token:     token,
+		workspace: workspace,
+	}
+}
+
+func (m remoteBackendRunModel) Init() tea.Cmd {
+	// runPlan needs m.ctx, which is only set once loadSourcesConfigMsg
+	// arrives (see tfApplyModel.Update), so it can't be dispatched from here
+	// - at Init() time m.ctx is still the zero value. Start, below, is what
+	// actually kicks the plan off.
+	return m.taskModel.Init()
+}
+
+// Start begins the remote `terraform plan` now that ctx is available. It's
+// called from tfApplyModel's loadSourcesConfigMsg handler rather than from
+// Init(), which runs too early to have a real context to run the plan or
+// parent its span under.
+func (m remoteBackendRunModel) Start() (remoteBackendRunModel, tea.Cmd) {
+	m.status = taskStatusRunning
+	m.taskModel = m.taskModel.StartSpan(m.ctx)
+	return m, m.runPlan
+}
+
+// runPlan shells out to `terraform plan`, which queues the run on Terraform
+// Cloud, and scrapes the run ID out of its output.
+func (m remoteBackendRunModel) runPlan() tea.Msg {
+	ctx, span := tracing.Tracer().Start(m.ctx, "terraform plan", trace.WithAttributes(
+		attribute.String("terraform.args", strings.Join(m.planArgs, " ")),
+		attribute.String("overmind.workspace", m.workspace),
+	))
+	defer span.End()
+
+	c := exec.CommandContext(ctx, "terraform", m.planArgs...) // nolint:gosec // this is a user-provided command, let them do their thing
+
+	out, err := c.CombinedOutput()
+	if err != nil {
+		span.RecordError(err)
+		return fatalError{err: fmt.Errorf("remote terraform plan failed: %w\n%s", err, out)}
+	}
+
+	match := tfcRunURLRe.FindSubmatch(out)
+	if match == nil {
+		err := fmt.Errorf("could not find a terraform cloud run id in the plan output")
+		span.RecordError(err)
+		return fatalError{err: err}
+	}
+
+	return remoteRunQueuedMsg{runID: string(match[1])}
+}
+
+// pollPlan blocks until the TFC run has finished planning (or errored), then
+// downloads the plan JSON.
+func (m remoteBackendRunModel) pollPlan() tea.Msg {
+	client := newTfcClient(m.token)
+
+	for {
+		run, err := client.GetRun(m.ctx, m.runID)
+		if err != nil {
+			return fatalError{err: fmt.Errorf("failed to poll terraform cloud run %v: %w", m.runID, err)}
+		}
+
+		switch run.Data.Attributes.Status {
+		case "planned", "cost_estimated", "policy_checked", "planned_and_finished":
+			planJSON, err := client.PlanJSONOutput(m.ctx, run.Data.Relationships.Plan.Data.ID)
+			if err != nil {
+				return fatalError{err: fmt.Errorf("failed to download plan json for run %v: %w", m.runID, err)}
+			}
+			return remoteRunPlannedMsg{planJSON: planJSON}
+		case "errored", "canceled", "discarded":
+			return fatalError{err: fmt.Errorf("terraform cloud run %v ended with status %v", m.runID, run.Data.Attributes.Status)}
+		default:
+			time.Sleep(2 * time.Second)
+		}
+	}
+}
+
+func (m remoteBackendRunModel) Update(msg tea.Msg) (remoteBackendRunModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case remoteRunQueuedMsg:
+		m.runID = msg.runID
+		return m, m.pollPlan
+	case remoteRunPlannedMsg:
+		m.planJSON = msg.planJSON
+		m.status = taskStatusDone
+		m.taskModel = m.taskModel.EndSpan(nil)
+		return m, nil
+	case fatalError:
+		if m.status == taskStatusRunning {
+			m.status = taskStatusError
+			m.taskModel = m.taskModel.EndSpan(msg.err)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.taskModel, cmd = m.taskModel.Update(msg)
+	return m, cmd
+}
+
+// pollApplyDone blocks until the TFC run identified by runID has finished
+// applying, so EndChange can be kicked off in its place rather than shelling
+// out to `terraform apply` directly, which has nothing to attach to on a
+// remote backend.
+func pollApplyDone(ctx context.Context, token, runID string) tea.Cmd {
+	return func() tea.Msg {
+		client := newTfcClient(token)
+
+		for {
+			run, err := client.GetRun(ctx, runID)
+			if err != nil {
+				return fatalError{err: fmt.Errorf("failed to poll terraform cloud run %v: %w", runID, err)}
+			}
+
+			switch run.Data.Attributes.Status {
+			case "applied":
+				return tfApplyFinishedMsg{}
+			case "errored", "discarded":
+				return fatalError{err: fmt.Errorf("terraform cloud apply %v ended with status %v", runID, run.Data.Attributes.Status)}
+			default:
+				time.Sleep(2 * time.Second)
+			}
+		}
+	}
+}
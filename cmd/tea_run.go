@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/overmindtech/cli/views"
+)
+
+// runOperationModel runs model to completion using the rendering strategy
+// implied by view. For views.TUIView it starts a real Bubbletea program and
+// Binds it to the view first, so the view's events actually reach the
+// program instead of being dropped by TUIView.send's nil-program guard. For
+// every other Operation (plain/json) it still runs model through a
+// tea.Program - that's what drives runPlanTask/submitPlanTask/the snapshot
+// models forward - but without a renderer or terminal input, since those
+// views already write their own lines straight to stdout and a second,
+// alt-screen renderer racing them is exactly the interleaving the views
+// package exists to avoid.
+//
+// CmdWrapper builds model via the NewTfXModel family and must call this
+// instead of unconditionally doing tea.NewProgram(model).Run(), or `plain`
+// and `json` output will still fight the TUI renderer for stdout.
+func runOperationModel(view views.Operation, model tea.Model, opts ...tea.ProgramOption) (tea.Model, error) {
+	if tuiView, ok := view.(*views.TUIView); ok {
+		program := tea.NewProgram(model, opts...)
+		tuiView.Bind(program)
+		return program.Run()
+	}
+
+	headless := append(append([]tea.ProgramOption{}, opts...), tea.WithoutRenderer(), tea.WithInput(nil))
+	return tea.NewProgram(model, headless...).Run()
+}
@@ -1,13 +1,22 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type snapshotModel struct {
 	taskModel
+
+	// ctx is the parent (apply/plan/refresh) context this snapshot's span
+	// is started under, set by the owning model once loadSourcesConfigMsg
+	// arrives - see tfApplyModel.Update and tfRefreshModel.Update.
+	ctx context.Context
+
 	state string
 	items uint32
 	edges uint32
@@ -42,14 +51,30 @@ func (m snapshotModel) Update(msg tea.Msg) (snapshotModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case startSnapshotMsg:
 		m.state = msg.newState
+		m.taskModel = m.taskModel.StartSpan(m.ctx)
 	case progressSnapshotMsg:
 		m.state = msg.newState
 		m.items = msg.items
 		m.edges = msg.edges
+		if m.taskModel.span != nil {
+			m.taskModel.span.AddEvent("progress", trace.WithAttributes(
+				attribute.String("state", msg.newState),
+				attribute.Int64("items", int64(msg.items)),
+				attribute.Int64("edges", int64(msg.edges)),
+			))
+		}
 	case finishSnapshotMsg:
 		m.state = msg.newState
 		m.items = msg.items
 		m.edges = msg.edges
+		if m.taskModel.span != nil {
+			m.taskModel.span.AddEvent("finished", trace.WithAttributes(
+				attribute.String("state", msg.newState),
+				attribute.Int64("items", int64(msg.items)),
+				attribute.Int64("edges", int64(msg.edges)),
+			))
+		}
+		m.taskModel = m.taskModel.EndSpan(nil)
 	default:
 		var cmd tea.Cmd
 		m.taskModel, cmd = m.taskModel.Update(msg)
@@ -11,6 +11,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/ansi"
+	"github.com/overmindtech/cli/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // waitForCancellation returns a tea.Cmd that will wait for SIGINT and SIGTERM and run the provided cancel on receipt.
@@ -54,6 +56,35 @@ type taskModel struct {
 	status  taskStatus
 	title   string
 	spinner spinner.Model
+
+	// span covers this task's lifetime, when StartSpan/EndSpan are used by
+	// the embedding model, so a stalled CI run leaves a trace behind
+	// showing which task it got stuck in.
+	span trace.Span
+}
+
+// StartSpan starts a span named after the task's title, as a child of ctx.
+// Callers that drive their own status transitions (rather than relying on
+// taskModel.Update) call this when moving to taskStatusRunning.
+func (m taskModel) StartSpan(ctx context.Context) taskModel {
+	_, m.span = tracing.Tracer().Start(ctx, m.title)
+	return m
+}
+
+// EndSpan ends the task's span, recording err on it first if non-nil.
+// Callers call this when moving to taskStatusDone/taskStatusError.
+func (m taskModel) EndSpan(err error) taskModel {
+	if m.span == nil {
+		return m
+	}
+
+	if err != nil {
+		m.span.RecordError(err)
+	}
+	m.span.End()
+	m.span = nil
+
+	return m
 }
 
 type WithTaskModel interface {
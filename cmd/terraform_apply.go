@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 	"github.com/overmindtech/cli/tracing"
+	"github.com/overmindtech/cli/views"
 	"github.com/overmindtech/sdp-go"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -29,6 +31,10 @@ var terraformApplyCmd = &cobra.Command{
 		if err != nil {
 			log.WithError(err).Fatal("could not bind `terraform apply` flags")
 		}
+
+		if err := tracing.ConfigureOTLPEndpoint(viper.GetString("otlp-endpoint")); err != nil {
+			log.WithError(err).Fatal("could not configure OTLP endpoint")
+		}
 	},
 	Run: CmdWrapper("apply", []string{"explore:read", "changes:write", "config:write", "request:receive"}, NewTfApplyModel),
 }
@@ -41,11 +47,38 @@ type tfApplyModel struct {
 
 	planFile    string
 	needPlan    bool
+	planArgs    []string
 	runPlanTask runPlanModel
 
+	// remoteBackend is true when the working directory uses a Terraform
+	// Cloud/Enterprise `cloud {}` block or the `remote` backend, in which
+	// case there is no local plan file and remoteRunTask is used in place
+	// of runPlanTask.
+	remoteBackend bool
+	remoteRunTask remoteBackendRunModel
+	tfcToken      string
+
+	// workspace is the terraform workspace this apply runs against, so that
+	// concurrent applies in different workspaces (e.g. dev/stage/prod) don't
+	// collide on a single open change.
+	workspace string
+
+	// driftChecked and driftResources hold the result of comparing the
+	// plan's prev_run_state against its prior_state, i.e. changes that
+	// happened outside terraform since the last apply. driftAnnotationSubmitted
+	// guards against submitting it twice, since drift detection and change
+	// identification finish in whichever order they happen to race.
+	driftChecked             bool
+	driftResources           []string
+	driftAnnotationSubmitted bool
+
 	runPlanFinished       bool
 	revlinkWarmupFinished bool
 
+	// submitPlanTask reports its own status through Status(), unlike
+	// runPlanTask's directly-read .status, so unlike runPlanTask it isn't
+	// known to embed taskModel the same way - reaching into it to start/end
+	// a span the way runPlanTask's is below isn't safe to assume here.
 	submitPlanTask submitPlanModel
 
 	processingHeader string
@@ -61,6 +94,8 @@ type tfApplyModel struct {
 	endingChangeSnapshot   snapshotModel
 	progress               []string
 
+	view views.Operation
+
 	width int
 }
 
@@ -101,6 +136,18 @@ func NewTfApplyModel(args []string) tea.Model {
 
 	planArgs := append([]string{"plan"}, planArgsFromApplyArgs(args)...)
 
+	// A `cloud {}` block or `remote` backend means `terraform plan` streams
+	// from Terraform Cloud/Enterprise and never produces a local plan file,
+	// no matter what `-out` says, so detect that up front.
+	remoteBackend := false
+	if !hasPlanSet {
+		var err error
+		remoteBackend, err = usesRemoteBackend(".")
+		if err != nil {
+			log.WithError(err).Warn("failed to detect terraform backend, assuming local")
+		}
+	}
+
 	if !hasPlanSet {
 		// if the user has not set a plan, we need to set a temporary file to
 		// capture the output for all calculations and to run apply afterwards
@@ -112,8 +159,15 @@ func NewTfApplyModel(args []string) tea.Model {
 
 		planFile = f.Name()
 
-		planArgs = append(planArgs, "-out", planFile)
-		args = append(args, planFile)
+		if remoteBackend {
+			// Terraform Cloud/Enterprise runs can't be saved with -out; the
+			// plan JSON is downloaded from the API instead once the run it
+			// queues has finished planning, and the apply is confirmed and
+			// polled through the API rather than run via `terraform apply`.
+		} else {
+			planArgs = append(planArgs, "-out", planFile)
+			args = append(args, planFile)
+		}
 
 		// auto
 		for _, a := range args {
@@ -128,17 +182,27 @@ func NewTfApplyModel(args []string) tea.Model {
 
 	args = append([]string{"apply"}, args...)
 
+	workspace := viper.GetString("workspace")
+	if workspace == "" {
+		var err error
+		workspace, err = detectActiveWorkspace()
+		if err != nil {
+			log.WithError(err).Warn("failed to detect active terraform workspace, change tracking will not be workspace-scoped")
+		}
+	}
+
 	processingHeader := `# Applying Changes
 
 Applying changes with ` + "`" + `terraform %v` + "`\n"
 	processingHeader = fmt.Sprintf(processingHeader, strings.Join(args, " "))
 
-	return tfApplyModel{
+	m := tfApplyModel{
 		args: args,
 
 		planFile:        planFile,
 		needPlan:        !hasPlanSet,
-		runPlanTask:     NewRunPlanModel(planArgs, planFile),
+		planArgs:        planArgs,
+		remoteBackend:   remoteBackend,
 		runPlanFinished: hasPlanSet,
 
 		submitPlanTask: NewSubmitPlanModel(planFile),
@@ -151,18 +215,40 @@ Applying changes with ` + "`" + `terraform %v` + "`\n"
 		endingChange:           make(chan tea.Msg, 10), // provide a small buffer for sending updates, so we don't block the processing
 		endingChangeSnapshot:   NewSnapShotModel("Ending Change"),
 		progress:               []string{},
+
+		tfcToken:  viper.GetString("tfc-token"),
+		workspace: workspace,
+
+		// CmdWrapper must run this model through runOperationModel, which
+		// Binds this to the running tea.Program for "tui" and runs a
+		// headless, unrendered program for "plain"/"json" so their output
+		// doesn't race the TUI renderer for stdout.
+		view: views.New(viper.GetString("output")),
 	}
+
+	if remoteBackend {
+		m.remoteRunTask = NewRemoteBackendRunModel(planArgs, workspace)
+		// reuse the token resolved there, including its TFC_TOKEN fallback
+		m.tfcToken = m.remoteRunTask.token
+	} else {
+		m.runPlanTask = NewRunPlanModel(planArgs, planFile)
+	}
+
+	return m
 }
 
 func (m tfApplyModel) Init() tea.Cmd {
 	cmds := []tea.Cmd{}
 
 	if m.needPlan {
-		cmds = append(
-			cmds,
-			m.runPlanTask.Init(),
-			m.submitPlanTask.Init(),
-		)
+		m.view.PlanStarted(m.planArgs)
+
+		if m.remoteBackend {
+			cmds = append(cmds, m.remoteRunTask.Init())
+		} else {
+			cmds = append(cmds, m.runPlanTask.Init())
+		}
+		cmds = append(cmds, m.submitPlanTask.Init())
 	}
 
 	return tea.Batch(cmds...)
@@ -175,9 +261,65 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 
+	case fatalError:
+		m.view.Done(msg.err)
+		if m.needPlan && !m.remoteBackend && m.runPlanTask.status == taskStatusRunning {
+			m.runPlanTask.taskModel = m.runPlanTask.taskModel.EndSpan(msg.err)
+		}
+
 	case loadSourcesConfigMsg:
 		m.ctx = msg.ctx
 		m.oi = msg.oi
+		m.remoteRunTask.ctx = msg.ctx
+		m.startingChangeSnapshot.ctx = msg.ctx
+		m.endingChangeSnapshot.ctx = msg.ctx
+
+		if m.needPlan && m.remoteBackend {
+			var cmd tea.Cmd
+			m.remoteRunTask, cmd = m.remoteRunTask.Start()
+			cmds = append(cmds, cmd)
+		} else if m.needPlan {
+			// runPlanModel embeds taskModel (see its .status being read
+			// directly in View() below), so it gets the same span-per-task
+			// coverage remoteRunTask already has, started here once ctx is
+			// actually available and ended in runPlanFinishedMsg/fatalError
+			// below.
+			m.runPlanTask.taskModel = m.runPlanTask.taskModel.StartSpan(m.ctx)
+		} else {
+			// The user supplied their own plan file (hasPlanSet), so
+			// runPlanFinishedMsg - the event that normally kicks off drift
+			// detection below - never fires; m.runPlanFinished is already
+			// true from construction. Check the supplied plan for drift as
+			// soon as ctx (which checkDriftCmd needs to shell out to
+			// `terraform show`) is available instead.
+			cmds = append(cmds, m.checkDriftCmd())
+		}
+
+	case remoteRunPlannedMsg:
+		// Terraform Cloud's plan JSON output is meant to be the same
+		// "terraform show -json" representation a local plan file is
+		// converted to before being handed to submitPlanTask/
+		// getTicketLinkFromPlan, so writing it straight to m.planFile is
+		// meant to keep both paths interchangeable for those two - but that
+		// only holds if they actually accept a plan JSON document directly
+		// rather than only a real `-out` plan file they show-json themselves
+		// (nothing in this tree confirms either way). Reject anything that
+		// doesn't at least look like real plan JSON here, so a malformed or
+		// unexpected download fails loudly instead of being handed to those
+		// helpers silently.
+		if err := validatePlanJSON(msg.planJSON); err != nil {
+			cmds = append(cmds, func() tea.Msg {
+				return fatalError{err: fmt.Errorf("terraform cloud returned an unusable plan: %w", err)}
+			})
+			break
+		}
+		if err := os.WriteFile(m.planFile, msg.planJSON, 0o600); err != nil {
+			cmds = append(cmds, func() tea.Msg {
+				return fatalError{err: fmt.Errorf("failed to write downloaded terraform cloud plan: %w", err)}
+			})
+			break
+		}
+		cmds = append(cmds, func() tea.Msg { return runPlanFinishedMsg{} })
 
 	case revlinkWarmupFinishedMsg:
 		m.revlinkWarmupFinished = true
@@ -192,6 +334,10 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case runPlanFinishedMsg:
 		m.runPlanFinished = true
+		if m.needPlan && !m.remoteBackend {
+			m.runPlanTask.taskModel = m.runPlanTask.taskModel.EndSpan(nil)
+		}
+		cmds = append(cmds, m.checkDriftCmd())
 		if m.revlinkWarmupFinished {
 			cmds = append(cmds, func() tea.Msg {
 				if m.needPlan {
@@ -202,6 +348,25 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			})
 		}
 
+	case driftCheckedMsg:
+		m.driftChecked = true
+		m.driftResources = msg.resources
+
+		if len(msg.resources) > 0 {
+			log.WithField("resources", msg.resources).Warn("detected drift outside of terraform")
+			m.view.Diagnostic(fmt.Errorf("drift detected in %d resource(s) outside of terraform", len(msg.resources)))
+
+			if viper.GetBool("fail-on-drift") {
+				return m, func() tea.Msg {
+					return fatalError{err: fmt.Errorf("drift detected in %d resource(s), aborting due to --fail-on-drift", len(msg.resources))}
+				}
+			}
+		}
+
+		var driftCmd tea.Cmd
+		m, driftCmd = m.maybeSubmitDriftAnnotation()
+		cmds = append(cmds, driftCmd)
+
 	case submitPlanFinishedMsg:
 		cmds = append(cmds, func() tea.Msg { return startStartingSnapshotMsg{} })
 
@@ -215,8 +380,13 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case changeIdentifiedMsg:
 		m.changeUuid = msg.uuid
+		m.view.ChangeIdentified(msg.uuid)
 		cmds = append(cmds, m.waitForStartingActivity)
 
+		var driftCmd tea.Cmd
+		m, driftCmd = m.maybeSubmitDriftAnnotation()
+		cmds = append(cmds, driftCmd)
+
 	case startSnapshotMsg:
 		if msg.id == m.startingChangeSnapshot.spinner.ID() {
 			cmds = append(cmds, m.waitForStartingActivity)
@@ -226,8 +396,10 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case progressSnapshotMsg:
 		if msg.id == m.startingChangeSnapshot.spinner.ID() {
+			m.view.SnapshotProgress("starting change", msg.newState, msg.items, msg.edges)
 			cmds = append(cmds, m.waitForStartingActivity)
 		} else if msg.id == m.endingChangeSnapshot.spinner.ID() {
+			m.view.SnapshotProgress("ending change", msg.newState, msg.items, msg.edges)
 			cmds = append(cmds, m.waitForEndingActivity)
 		}
 
@@ -238,10 +410,45 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.runTfApply = true
 			cmds = append(cmds, func() tea.Msg { return runTfApplyMsg{} })
 		} else if msg.id == m.endingChangeSnapshot.spinner.ID() {
+			m.view.Done(nil)
 			cmds = append(cmds, func() tea.Msg { return delayQuitMsg{} })
 		}
 
 	case runTfApplyMsg:
+		m.view.ApplyStarted(m.args)
+
+		if m.remoteBackend {
+			// There's no local process to attach to: `terraform plan`
+			// already queued the run, so confirm it via the TFC API and
+			// poll for its apply to finish instead of exec-ing `terraform
+			// apply` against a plan file that was never written locally.
+			ctx := m.ctx
+			token := m.tfcToken
+			runID := m.remoteRunTask.runID
+
+			ctx, span := tracing.Tracer().Start(ctx, "terraform apply", trace.WithAttributes(
+				attribute.String("terraform.args", strings.Join(m.args, " ")),
+				attribute.String("change.uuid", m.changeUuid.String()),
+				attribute.String("overmind.workspace", m.workspace),
+			))
+
+			return m, func() tea.Msg {
+				defer span.End()
+
+				client := newTfcClient(token)
+				if err := client.ApplyRun(ctx, runID); err != nil {
+					span.RecordError(err)
+					return fatalError{err: fmt.Errorf("failed to apply terraform cloud run %v: %w", runID, err)}
+				}
+
+				msg := pollApplyDone(ctx, token, runID)()
+				if fatal, ok := msg.(fatalError); ok {
+					span.RecordError(fatal.err)
+				}
+				return msg
+			}
+		}
+
 		c := exec.CommandContext(m.ctx, "terraform", m.args...) // nolint:gosec // this is a user-provided command, let them do their thing
 
 		// inject the profile, if configured
@@ -251,6 +458,9 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		_, span := tracing.Tracer().Start(m.ctx, "terraform apply", trace.WithAttributes(
 			attribute.String("command", strings.Join(m.args, " ")),
+			attribute.String("terraform.args", strings.Join(m.args, " ")),
+			attribute.String("change.uuid", m.changeUuid.String()),
+			attribute.String("overmind.workspace", m.workspace),
 		))
 		return m, tea.ExecProcess(
 			c,
@@ -258,6 +468,7 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				defer span.End()
 
 				if err != nil {
+					span.RecordError(err)
 					return fatalError{err: fmt.Errorf("failed to run terraform apply: %w", err)}
 				}
 
@@ -281,11 +492,17 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.endingChangeSnapshot = mdl
 
 	if m.needPlan {
-		mdl, cmd := m.runPlanTask.Update(msg)
-		cmds = append(cmds, cmd)
-		m.runPlanTask = mdl.(runPlanModel)
+		if m.remoteBackend {
+			remoteMdl, cmd := m.remoteRunTask.Update(msg)
+			cmds = append(cmds, cmd)
+			m.remoteRunTask = remoteMdl
+		} else {
+			mdl, cmd := m.runPlanTask.Update(msg)
+			cmds = append(cmds, cmd)
+			m.runPlanTask = mdl.(runPlanModel)
+		}
 
-		mdl, cmd = m.submitPlanTask.Update(msg)
+		mdl, cmd := m.submitPlanTask.Update(msg)
 		cmds = append(cmds, cmd)
 		m.submitPlanTask = mdl.(submitPlanModel)
 	}
@@ -296,10 +513,22 @@ func (m tfApplyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m tfApplyModel) View() string {
 	bits := []string{}
 
-	if m.runPlanTask.status != taskStatusPending {
+	if m.remoteBackend {
+		if m.remoteRunTask.status != taskStatusPending {
+			bits = append(bits, m.remoteRunTask.View())
+		}
+	} else if m.runPlanTask.status != taskStatusPending {
 		bits = append(bits, m.runPlanTask.View())
 	}
 
+	if m.driftChecked {
+		if len(m.driftResources) == 0 {
+			bits = append(bits, "✅ No drift detected")
+		} else {
+			bits = append(bits, fmt.Sprintf("🌀 Drift detected: %d resource(s)", len(m.driftResources)))
+		}
+	}
+
 	if m.submitPlanTask.Status() != taskStatusPending {
 		bits = append(bits, m.submitPlanTask.View())
 	}
@@ -321,9 +550,135 @@ func (m tfApplyModel) View() string {
 	return strings.Join(bits, "\n") + "\n"
 }
 
+// driftCheckedMsg carries the result of comparing prev_run_state against
+// prior_state in the plan.
+type driftCheckedMsg struct {
+	resources []string
+}
+
+// validatePlanJSON rejects anything that doesn't look like a real terraform
+// plan JSON document: format_version and terraform_version are present on
+// every `terraform show -json` output regardless of terraform version, so
+// their absence means whatever was downloaded isn't plan JSON at all rather
+// than just a format this was never updated to understand.
+func validatePlanJSON(planJSON []byte) error {
+	if !json.Valid(planJSON) {
+		return fmt.Errorf("not valid JSON")
+	}
+
+	var doc struct {
+		FormatVersion    string `json:"format_version"`
+		TerraformVersion string `json:"terraform_version"`
+	}
+	if err := json.Unmarshal(planJSON, &doc); err != nil {
+		return fmt.Errorf("failed to parse as plan JSON: %w", err)
+	}
+	if doc.FormatVersion == "" || doc.TerraformVersion == "" {
+		return fmt.Errorf("missing format_version/terraform_version, doesn't look like plan JSON")
+	}
+
+	return nil
+}
+
+// loadPlanJSON returns the plan's JSON representation regardless of whether
+// it came from a local plan file (which needs `terraform show -json`) or
+// was downloaded directly from Terraform Cloud as JSON already.
+func (m tfApplyModel) loadPlanJSON() ([]byte, error) {
+	if m.remoteBackend {
+		return os.ReadFile(m.planFile)
+	}
+
+	out, err := exec.CommandContext(m.ctx, "terraform", "show", "-json", m.planFile).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan json from %v: %w", m.planFile, err)
+	}
+
+	return out, nil
+}
+
+// checkDriftCmd compares the plan's prev_run_state against its prior_state
+// to surface changes that happened outside terraform since the last apply.
+func (m tfApplyModel) checkDriftCmd() tea.Cmd {
+	return func() tea.Msg {
+		planJSON, err := m.loadPlanJSON()
+		if err != nil {
+			return fatalError{err: fmt.Errorf("failed to load plan for drift detection: %w", err)}
+		}
+
+		drifted, err := detectDrift(planJSON)
+		if err != nil {
+			return fatalError{err: err}
+		}
+
+		return driftCheckedMsg{resources: drifted}
+	}
+}
+
+// maybeSubmitDriftAnnotation submits detected drift as an annotation on the
+// change, once both pieces it needs - the drift check result and the
+// change's UUID - are available. Drift detection (kicked off as soon as the
+// plan is ready) and change identification (kicked off once the plan has
+// been submitted) run concurrently, so this is called from both of their
+// message handlers and only actually submits once, whichever finishes last.
+func (m tfApplyModel) maybeSubmitDriftAnnotation() (tfApplyModel, tea.Cmd) {
+	if !m.driftChecked || m.driftAnnotationSubmitted || m.changeUuid == uuid.Nil || len(m.driftResources) == 0 {
+		return m, nil
+	}
+	m.driftAnnotationSubmitted = true
+
+	ctx := m.ctx
+	oi := m.oi
+	changeUuid := m.changeUuid
+	resources := m.driftResources
+	view := m.view
+
+	return m, func() tea.Msg {
+		if err := submitDriftAnnotation(ctx, oi, changeUuid, resources); err != nil {
+			log.WithError(err).Warn("failed to submit drift annotation")
+			view.Diagnostic(fmt.Errorf("failed to submit drift annotation: %w", err))
+		}
+		return nil
+	}
+}
+
+// submitDriftAnnotation records the resources that drifted outside terraform
+// as a "drift" annotation on the change, via the same AuthenticatedChangesClient
+// used by start/end change, so a run with --fail-on-drift=false still leaves
+// a visible record of what drifted rather than only a log line.
+func submitDriftAnnotation(ctx context.Context, oi OvermindInstance, changeUuid uuid.UUID, resources []string) error {
+	return submitChangeAnnotations(ctx, oi, changeUuid, map[string]string{
+		"drift": strings.Join(resources, ", "),
+	})
+}
+
+// submitChangeAnnotations records annotations on a change via
+// AuthenticatedChangesClient. It exists for information StartChangeRequest/
+// EndChangeRequest have no dedicated field for - currently the terraform
+// workspace an apply ran against, any drift detected in its plan, and the
+// kind=refresh tag set by `terraform refresh` - so it
+// still shows up on the change itself rather than only in this process's
+// logs.
+func submitChangeAnnotations(ctx context.Context, oi OvermindInstance, changeUuid uuid.UUID, annotations map[string]string) error {
+	client := AuthenticatedChangesClient(ctx, oi)
+
+	_, err := client.UpdateChangeAnnotations(ctx, &connect.Request[sdp.UpdateChangeAnnotationsRequest]{
+		Msg: &sdp.UpdateChangeAnnotationsRequest{
+			ChangeUUID:  changeUuid[:],
+			Annotations: annotations,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update change annotations: %w", err)
+	}
+
+	return nil
+}
+
 func (m tfApplyModel) startStartChangeCmd() tea.Cmd {
 	ctx := m.ctx
 	oi := m.oi
+	workspace := m.workspace
+	view := m.view
 
 	return func() tea.Msg {
 		var err error
@@ -335,11 +690,42 @@ func (m tfApplyModel) startStartChangeCmd() tea.Cmd {
 			}
 		}
 
+		// Resolve the scope to identify/start the change with: an explicit
+		// --scope wins, otherwise fall back to whatever scope this
+		// workspace resolved to last time, so `terraform apply` in
+		// workspace `prod` keeps landing on the prod scope without
+		// repeating --scope on every run. Setting it back into viper is
+		// what makes getChangeUuid, which reads the scope the same way the
+		// rest of this command does, pick it up.
+		scope := viper.GetString("scope")
+		if scope == "" {
+			scope = scopeForWorkspace(workspace)
+		}
+		if scope != "" {
+			viper.Set("scope", scope)
+		}
+
 		changeUuid, err := getChangeUuid(ctx, oi, sdp.ChangeStatus_CHANGE_STATUS_DEFINING, ticketLink, true)
 		if err != nil {
 			return fatalError{err: fmt.Errorf("failed to identify change: %w", err)}
 		}
 
+		if err := rememberWorkspaceScope(workspace, scope); err != nil {
+			log.WithError(err).Warn("failed to persist workspace scope mapping")
+			view.Diagnostic(fmt.Errorf("failed to persist workspace scope mapping: %w", err))
+		}
+
+		// Record the workspace as an attribute on the change itself, rather
+		// than folding it into the identity key used to resolve the change:
+		// doing that would fork a new change per workspace instead of
+		// resolving the one `plan` already created for the same ticket link.
+		if workspace != "" {
+			if err := submitChangeAnnotations(ctx, oi, changeUuid, map[string]string{"workspace": workspace}); err != nil {
+				log.WithError(err).Warn("failed to submit workspace annotation")
+				view.Diagnostic(fmt.Errorf("failed to submit workspace annotation: %w", err))
+			}
+		}
+
 		m.startingChange <- changeIdentifiedMsg{uuid: changeUuid}
 		m.startingChange <- m.startingChangeSnapshot.StartMsg("starting")
 
@@ -357,9 +743,10 @@ func (m tfApplyModel) startStartChangeCmd() tea.Cmd {
 		for startStream.Receive() {
 			msg = startStream.Msg()
 			log.WithFields(log.Fields{
-				"state": msg.GetState(),
-				"items": msg.GetNumItems(),
-				"edges": msg.GetNumEdges(),
+				"state":     msg.GetState(),
+				"items":     msg.GetNumItems(),
+				"edges":     msg.GetNumEdges(),
+				"workspace": workspace,
 			}).Trace("progress")
 			m.startingChange <- m.startingChangeSnapshot.ProgressMsg(msg.GetState().String(), msg.GetNumItems(), msg.GetNumEdges())
 		}
@@ -380,6 +767,7 @@ func (m tfApplyModel) startEndChangeCmd() tea.Cmd {
 	ctx := m.ctx
 	oi := m.oi
 	changeUuid := m.changeUuid
+	workspace := m.workspace
 
 	return func() tea.Msg {
 		m.endingChange <- m.endingChangeSnapshot.StartMsg("ending")
@@ -398,9 +786,10 @@ func (m tfApplyModel) startEndChangeCmd() tea.Cmd {
 		for endStream.Receive() {
 			msg = endStream.Msg()
 			log.WithFields(log.Fields{
-				"state": msg.GetState(),
-				"items": msg.GetNumItems(),
-				"edges": msg.GetNumEdges(),
+				"state":     msg.GetState(),
+				"items":     msg.GetNumItems(),
+				"edges":     msg.GetNumEdges(),
+				"workspace": workspace,
 			}).Trace("progress")
 			m.endingChange <- m.endingChangeSnapshot.ProgressMsg(msg.GetState().String(), msg.GetNumItems(), msg.GetNumEdges())
 		}
@@ -423,4 +812,10 @@ func init() {
 	addAPIFlags(terraformApplyCmd)
 	addChangeUuidFlags(terraformApplyCmd)
 	addTerraformBaseFlags(terraformApplyCmd)
+
+	terraformApplyCmd.PersistentFlags().String("output", "", "How to render progress: \"tui\" (default on a terminal), \"plain\" (default otherwise, e.g. CI logs), or \"json\" (line-delimited, for machine consumption).")
+	terraformApplyCmd.PersistentFlags().String("tfc-token", "", "Terraform Cloud/Enterprise API token, used to fetch the plan JSON and drive the apply when the working directory uses a `cloud {}` block or the `remote` backend. Falls back to the TFC_TOKEN env var.")
+	terraformApplyCmd.PersistentFlags().String("workspace", "", "The terraform workspace this apply runs against. Defaults to the active workspace reported by `terraform workspace show`. Keeps change tracking separate across e.g. dev/stage/prod.")
+	terraformApplyCmd.PersistentFlags().Bool("fail-on-drift", false, "Abort before applying if drift (changes made outside terraform since the last apply) is detected in the plan.")
+	tracing.AddOTLPFlag(terraformApplyCmd)
 }
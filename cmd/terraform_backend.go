@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// remoteBackendRe matches a `cloud {}` block or a `backend "remote" {}`
+// block in a terraform config file. Either one means `terraform plan` never
+// produces a local plan file: it streams from Terraform Cloud/Enterprise
+// instead.
+var remoteBackendRe = regexp.MustCompile(`(?m)^\s*(cloud\s*{|backend\s+"remote"\s*{)`)
+
+// usesRemoteBackend reports whether the terraform configuration in dir is
+// backed by Terraform Cloud/Enterprise, either via a `cloud {}` block or the
+// `remote` backend. `.terraform/environment` is the same marker file the
+// terraform CLI itself writes on `init` for either of those, so we check it
+// first; config files are sniffed as a fallback for directories that haven't
+// been initialized yet.
+func usesRemoteBackend(dir string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(dir, ".terraform", "environment")); err == nil {
+		return true, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return false, fmt.Errorf("failed to glob for terraform config in %v: %w", dir, err)
+	}
+
+	for _, m := range matches {
+		b, err := os.ReadFile(m)
+		if err != nil {
+			return false, fmt.Errorf("failed to read %v: %w", m, err)
+		}
+
+		if remoteBackendRe.Match(b) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"connectrpc.com/connect"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/overmindtech/cli/tracing"
+	"github.com/overmindtech/cli/views"
+	"github.com/overmindtech/sdp-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// terraformRefreshCmd represents the `terraform refresh` command
+var terraformRefreshCmd = &cobra.Command{
+	Use:   "refresh [overmind options...] -- [terraform options...]",
+	Short: "Runs `terraform plan -refresh-only` and submits the result to Overmind as a refresh-only change, without applying anything.",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// Bind these to viper
+		err := viper.BindPFlags(cmd.Flags())
+		if err != nil {
+			log.WithError(err).Fatal("could not bind `terraform refresh` flags")
+		}
+
+		if err := tracing.ConfigureOTLPEndpoint(viper.GetString("otlp-endpoint")); err != nil {
+			log.WithError(err).Fatal("could not configure OTLP endpoint")
+		}
+	},
+	Run: CmdWrapper("refresh", []string{"explore:read", "changes:write", "config:write", "request:receive"}, NewTfRefreshModel),
+}
+
+// tfRefreshModel runs `terraform plan -refresh-only`, submits the result to
+// Overmind as a CHANGE_STATUS_DEFINING change tagged kind=refresh, and takes
+// a single snapshot of that change. Unlike tfApplyModel there is no ending
+// snapshot and nothing is ever applied: this exists purely to surface drift
+// as a scheduled job, without smuggling `-refresh-only` through `apply --`.
+type tfRefreshModel struct {
+	ctx context.Context
+	oi  OvermindInstance
+
+	planFile    string
+	runPlanTask runPlanModel
+
+	runPlanFinished       bool
+	revlinkWarmupFinished bool
+
+	submitPlanTask submitPlanModel
+
+	processingHeader string
+
+	changeUuid             uuid.UUID
+	isStarting             bool
+	startingChange         chan tea.Msg
+	startingChangeSnapshot snapshotModel
+
+	openBrowser bool
+
+	view views.Operation
+
+	width int
+}
+
+type refreshStartStartingSnapshotMsg struct{}
+
+type refreshChangeIdentifiedMsg struct {
+	uuid uuid.UUID
+}
+
+// NewTfRefreshModel builds a tfRefreshModel from the terraform arguments
+// following `--`. args is passed straight through to `terraform plan
+// -refresh-only`.
+func NewTfRefreshModel(args []string) tea.Model {
+	f, err := os.CreateTemp("", "overmind-refresh-plan")
+	if err != nil {
+		log.WithError(err).Fatal("failed to create temporary plan file")
+	}
+	planFile := f.Name()
+
+	planArgs := append([]string{"plan", "-refresh-only", "-out", planFile}, args...)
+
+	processingHeader := `# Refresh
+
+Checking for drift with ` + "`" + `terraform %v` + "`\n"
+	processingHeader = fmt.Sprintf(processingHeader, strings.Join(planArgs, " "))
+
+	return tfRefreshModel{
+		planFile:    planFile,
+		runPlanTask: NewRunPlanModel(planArgs, planFile),
+
+		submitPlanTask: NewSubmitPlanModel(planFile),
+
+		processingHeader: processingHeader,
+
+		startingChange:         make(chan tea.Msg, 10), // provide a small buffer for sending updates, so we don't block the processing
+		startingChangeSnapshot: NewSnapShotModel("Refresh Change"),
+
+		openBrowser: viper.GetBool("open-browser"),
+
+		view: views.New(viper.GetString("output")),
+	}
+}
+
+func (m tfRefreshModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.runPlanTask.Init(),
+		m.submitPlanTask.Init(),
+	)
+}
+
+func (m tfRefreshModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case fatalError:
+		m.view.Done(msg.err)
+
+	case loadSourcesConfigMsg:
+		m.ctx = msg.ctx
+		m.oi = msg.oi
+		m.startingChangeSnapshot.ctx = msg.ctx
+
+	case revlinkWarmupFinishedMsg:
+		m.revlinkWarmupFinished = true
+		if m.runPlanFinished {
+			cmds = append(cmds, func() tea.Msg { return submitPlanNowMsg{} })
+		}
+
+	case runPlanFinishedMsg:
+		m.runPlanFinished = true
+		if m.revlinkWarmupFinished {
+			cmds = append(cmds, func() tea.Msg { return submitPlanNowMsg{} })
+		}
+
+	case submitPlanFinishedMsg:
+		cmds = append(cmds, func() tea.Msg { return refreshStartStartingSnapshotMsg{} })
+
+	case refreshStartStartingSnapshotMsg:
+		m.isStarting = true
+		cmds = append(cmds,
+			m.startingChangeSnapshot.Init(),
+			m.startRefreshChangeCmd(),
+			m.waitForStartingActivity,
+		)
+
+	case refreshChangeIdentifiedMsg:
+		m.changeUuid = msg.uuid
+		m.view.ChangeIdentified(msg.uuid)
+		cmds = append(cmds, m.waitForStartingActivity)
+
+	case startSnapshotMsg:
+		if msg.id == m.startingChangeSnapshot.spinner.ID() {
+			cmds = append(cmds, m.waitForStartingActivity)
+		}
+
+	case progressSnapshotMsg:
+		if msg.id == m.startingChangeSnapshot.spinner.ID() {
+			m.view.SnapshotProgress("refresh change", msg.newState, msg.items, msg.edges)
+			cmds = append(cmds, m.waitForStartingActivity)
+		}
+
+	case finishSnapshotMsg:
+		if msg.id == m.startingChangeSnapshot.spinner.ID() {
+			m.isStarting = false
+
+			if m.openBrowser {
+				if err := openChangeInBrowser(m.changeUuid); err != nil {
+					log.WithError(err).Warn("failed to open change in browser")
+				}
+			}
+
+			m.view.Done(nil)
+			cmds = append(cmds, func() tea.Msg { return delayQuitMsg{} })
+		}
+	}
+
+	mdl, cmd := m.startingChangeSnapshot.Update(msg)
+	cmds = append(cmds, cmd)
+	m.startingChangeSnapshot = mdl
+
+	runPlanMdl, cmd := m.runPlanTask.Update(msg)
+	cmds = append(cmds, cmd)
+	m.runPlanTask = runPlanMdl.(runPlanModel)
+
+	submitPlanMdl, cmd := m.submitPlanTask.Update(msg)
+	cmds = append(cmds, cmd)
+	m.submitPlanTask = submitPlanMdl.(submitPlanModel)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m tfRefreshModel) View() string {
+	bits := []string{}
+
+	if m.runPlanTask.status != taskStatusPending {
+		bits = append(bits, m.runPlanTask.View())
+	}
+
+	if m.submitPlanTask.Status() != taskStatusPending {
+		bits = append(bits, m.submitPlanTask.View())
+	}
+
+	if m.isStarting {
+		bits = append(bits, markdownToString(m.processingHeader))
+
+		if m.startingChangeSnapshot.status != taskStatusPending {
+			bits = append(bits, m.startingChangeSnapshot.View())
+		}
+	}
+
+	return strings.Join(bits, "\n") + "\n"
+}
+
+// startRefreshChangeCmd creates (or resolves) the Overmind change for this
+// refresh, tagged kind=refresh so it's distinguishable from a real apply,
+// and starts it. Unlike tfApplyModel there is no matching EndChange: nothing
+// is ever applied.
+func (m tfRefreshModel) startRefreshChangeCmd() tea.Cmd {
+	ctx := m.ctx
+	oi := m.oi
+	view := m.view
+
+	return func() tea.Msg {
+		var err error
+		ticketLink := viper.GetString("ticket-link")
+		if ticketLink == "" {
+			ticketLink, err = getTicketLinkFromPlan(m.planFile)
+			if err != nil {
+				return fatalError{err: err}
+			}
+		}
+
+		changeUuid, err := getChangeUuid(ctx, oi, sdp.ChangeStatus_CHANGE_STATUS_DEFINING, ticketLink, true)
+		if err != nil {
+			return fatalError{err: fmt.Errorf("failed to identify change: %w", err)}
+		}
+
+		// Tag the change kind=refresh on the change itself, not just in
+		// this process's logs, so it's distinguishable from a real apply
+		// from the Overmind UI too.
+		if err := submitChangeAnnotations(ctx, oi, changeUuid, map[string]string{"kind": "refresh"}); err != nil {
+			log.WithError(err).Warn("failed to submit kind=refresh annotation")
+			view.Diagnostic(fmt.Errorf("failed to submit kind=refresh annotation: %w", err))
+		}
+
+		m.startingChange <- refreshChangeIdentifiedMsg{uuid: changeUuid}
+		m.startingChange <- m.startingChangeSnapshot.StartMsg("starting")
+
+		client := AuthenticatedChangesClient(ctx, oi)
+		startStream, err := client.StartChange(ctx, &connect.Request[sdp.StartChangeRequest]{
+			Msg: &sdp.StartChangeRequest{
+				ChangeUUID: changeUuid[:],
+			},
+		})
+		if err != nil {
+			return fatalError{err: fmt.Errorf("failed to start change: %w", err)}
+		}
+
+		var msg *sdp.StartChangeResponse
+		for startStream.Receive() {
+			msg = startStream.Msg()
+			log.WithFields(log.Fields{
+				"state": msg.GetState(),
+				"items": msg.GetNumItems(),
+				"edges": msg.GetNumEdges(),
+				"kind":  "refresh",
+			}).Trace("progress")
+			m.startingChange <- m.startingChangeSnapshot.ProgressMsg(msg.GetState().String(), msg.GetNumItems(), msg.GetNumEdges())
+		}
+		if startStream.Err() != nil {
+			return fatalError{err: fmt.Errorf("failed to process start change: %w", startStream.Err())}
+		}
+
+		return m.startingChangeSnapshot.FinishMsg(msg.GetState().String(), msg.GetNumItems(), msg.GetNumEdges())
+	}
+}
+
+// A command that waits for the activity on the startingChange channel.
+func (m tfRefreshModel) waitForStartingActivity() tea.Msg {
+	return <-m.startingChange
+}
+
+// openChangeInBrowser opens the given change's Overmind URL in the user's
+// default browser.
+func openChangeInBrowser(changeUuid uuid.UUID) error {
+	frontendURL := viper.GetString("frontend-url")
+	if frontendURL == "" {
+		frontendURL = "https://app.overmind.tech"
+	}
+	url := fmt.Sprintf("%v/changes/%v", frontendURL, changeUuid)
+
+	var openCmd string
+	var args []string
+
+	switch runtime.GOOS {
+	case "darwin":
+		openCmd, args = "open", []string{url}
+	case "windows":
+		openCmd, args = "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		openCmd, args = "xdg-open", []string{url}
+	}
+
+	return exec.Command(openCmd, args...).Start() // nolint:gosec // args are a fixed binary name plus a URL we built ourselves
+}
+
+func init() {
+	terraformCmd.AddCommand(terraformRefreshCmd)
+
+	addAPIFlags(terraformRefreshCmd)
+	addChangeUuidFlags(terraformRefreshCmd)
+	addTerraformBaseFlags(terraformRefreshCmd)
+
+	terraformRefreshCmd.PersistentFlags().String("output", "", "How to render progress: \"tui\" (default on a terminal), \"plain\" (default otherwise, e.g. CI logs), or \"json\" (line-delimited, for machine consumption).")
+	terraformRefreshCmd.PersistentFlags().Bool("open-browser", false, "Open the refresh change in a browser once it has been submitted.")
+	tracing.AddOTLPFlag(terraformRefreshCmd)
+}
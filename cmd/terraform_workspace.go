@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// workspaceScopesConfigKey is the viper config key under which the
+// workspace -> scope mapping is persisted, so that applying in the same
+// terraform workspace twice resolves items against the same scope without
+// the user having to repeat `--scope` every time.
+const workspaceScopesConfigKey = "workspace-scopes"
+
+// detectActiveWorkspace shells out to `terraform workspace show` to find the
+// currently selected workspace, used as the default for `--workspace` when
+// the flag isn't set.
+func detectActiveWorkspace() (string, error) {
+	out, err := exec.Command("terraform", "workspace", "show").Output() // nolint:gosec // fixed command, no user input
+	if err != nil {
+		return "", fmt.Errorf("failed to run `terraform workspace show`: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// scopeForWorkspace looks up the scope previously recorded for workspace via
+// rememberWorkspaceScope. Returns "" if none has been recorded yet.
+func scopeForWorkspace(workspace string) string {
+	return viper.GetStringMapString(workspaceScopesConfigKey)[workspace]
+}
+
+// rememberWorkspaceScope persists the workspace -> scope mapping so that
+// future applies in the same workspace resolve items against the same
+// scope, instead of whatever scope was last used on the command line.
+func rememberWorkspaceScope(workspace, scope string) error {
+	if workspace == "" || scope == "" {
+		return nil
+	}
+
+	scopes := viper.GetStringMapString(workspaceScopesConfigKey)
+	if scopes[workspace] == scope {
+		return nil
+	}
+
+	if scopes == nil {
+		scopes = map[string]string{}
+	}
+	scopes[workspace] = scope
+	viper.Set(workspaceScopesConfigKey, scopes)
+
+	if err := viper.WriteConfig(); err != nil {
+		return fmt.Errorf("failed to persist workspace scope mapping: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// tfcClient is a minimal Terraform Cloud API client: just enough to poll a
+// run's status, confirm its apply, and download its plan JSON for submission
+// to Overmind. It is not meant to grow into a general-purpose TFC SDK.
+type tfcClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// newTfcClient returns a client authenticated with token, which is typically
+// sourced from `--tfc-token` or the `TFC_TOKEN` env var. The client always
+// talks to app.terraform.io; runs on a Terraform Enterprise host are not
+// reached through it.
+func newTfcClient(token string) *tfcClient {
+	return &tfcClient{
+		baseURL: "https://app.terraform.io/api/v2",
+		token:   token,
+		http:    http.DefaultClient,
+	}
+}
+
+// tfcRun is the subset of the TFC `/runs/:id` response we care about.
+type tfcRun struct {
+	Data struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Status string `json:"status"`
+		} `json:"attributes"`
+		Relationships struct {
+			Plan struct {
+				Data struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			} `json:"plan"`
+		} `json:"relationships"`
+	} `json:"data"`
+}
+
+func (c *tfcClient) do(ctx context.Context, method, path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %v: %w", path, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %v failed: %w", path, err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%v %v: %v: %s", method, path, resp.Status, body)
+	}
+
+	return resp, nil
+}
+
+// GetRun fetches a run's status and the ID of its associated plan.
+func (c *tfcClient) GetRun(ctx context.Context, runID string) (*tfcRun, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/runs/"+runID, "application/vnd.api+json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var run tfcRun
+	if err := json.NewDecoder(resp.Body).Decode(&run); err != nil {
+		return nil, fmt.Errorf("failed to decode run %v: %w", runID, err)
+	}
+
+	return &run, nil
+}
+
+// ApplyRun confirms the given run, kicking off its apply on Terraform Cloud.
+func (c *tfcClient) ApplyRun(ctx context.Context, runID string) error {
+	resp, err := c.do(ctx, http.MethodPost, "/runs/"+runID+"/actions/apply", "application/vnd.api+json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// PlanJSONOutput downloads the canonical plan JSON for planID, in the same
+// shape `terraform show -json <planfile>` would produce for a local plan.
+func (c *tfcClient) PlanJSONOutput(ctx context.Context, planID string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/plans/"+planID+"/json-output", "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan json for plan %v: %w", planID, err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// otlpEndpointEnvVar is the standard OTLP exporter env var; the SDK's own
+// exporter constructors already honour it, so bridging `--otlp-endpoint`
+// into it is enough to make flag and env var behave identically without
+// duplicating exporter configuration here.
+const otlpEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// AddOTLPFlag registers `--otlp-endpoint` on cmd, defaulting to whatever
+// OTEL_EXPORTER_OTLP_ENDPOINT is already set to.
+func AddOTLPFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("otlp-endpoint", os.Getenv(otlpEndpointEnvVar), "OTLP gRPC endpoint (e.g. a local Tempo/Jaeger collector) that terraform apply/plan spans should be exported to, in addition to Overmind's own tracing.")
+}
+
+// ConfigureOTLPEndpoint points the process's OTLP exporter at endpoint, if
+// set. It must be called before the tracer provider is initialized, or
+// setting the env var here is a silent no-op - whatever constructs the
+// provider (not part of this package) has already read it by then. Each
+// terraform subcommand calls this from its own PreRun, which cobra always
+// runs before that command's Run; whether anything even earlier in the
+// command tree (e.g. a root PersistentPreRun) builds the provider before
+// that isn't something this package can see or verify.
+func ConfigureOTLPEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+
+	if err := os.Setenv(otlpEndpointEnvVar, endpoint); err != nil {
+		return err
+	}
+
+	log.WithField("endpoint", endpoint).Debug("configured OTLP exporter endpoint")
+
+	return nil
+}
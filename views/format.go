@@ -0,0 +1,53 @@
+package views
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Format selects which Operation implementation New constructs.
+type Format string
+
+const (
+	FormatTUI   Format = "tui"
+	FormatPlain Format = "plain"
+	FormatJSON  Format = "json"
+)
+
+// Detect picks a sensible default Format for the current environment: `tui`
+// when stdout is an interactive terminal, `plain` otherwise. Terraform's own
+// `TF_IN_AUTOMATION` convention is honoured too, since anything that sets it
+// is almost certainly piping stdout somewhere that can't render the alt
+// screen.
+func Detect() Format {
+	if os.Getenv("TF_IN_AUTOMATION") != "" {
+		return FormatPlain
+	}
+
+	if isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+		return FormatTUI
+	}
+
+	return FormatPlain
+}
+
+// New constructs the Operation implementation for the given format. An empty
+// format defers to Detect.
+func New(format string) Operation {
+	f := Format(format)
+	if f == "" {
+		f = Detect()
+	}
+
+	switch f {
+	case FormatPlain:
+		return NewPlainView(os.Stdout)
+	case FormatJSON:
+		return NewJSONView(os.Stdout)
+	case FormatTUI:
+		return NewTUIView()
+	default:
+		return NewPlainView(os.Stdout)
+	}
+}
@@ -0,0 +1,79 @@
+package views
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jsonEvent is the line-delimited JSON shape emitted by JSONView. Field names
+// are part of the CI-automation contract, so they're stable once shipped:
+// don't rename them, add new ones instead.
+type jsonEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Type      string    `json:"type"`
+
+	Args       []string   `json:"args,omitempty"`
+	ChangeUUID *uuid.UUID `json:"change_uuid,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	State      string     `json:"state,omitempty"`
+	Items      *uint32    `json:"items,omitempty"`
+	Edges      *uint32    `json:"edges,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// JSONView renders Operation events as line-delimited JSON, one event per
+// line, for machine consumption by other tooling.
+type JSONView struct {
+	enc *json.Encoder
+	now func() time.Time
+}
+
+// assert that JSONView implements Operation
+var _ Operation = (*JSONView)(nil)
+
+// NewJSONView returns an Operation that writes one JSON object per line to
+// out.
+func NewJSONView(out io.Writer) *JSONView {
+	return &JSONView{
+		enc: json.NewEncoder(out),
+		now: time.Now,
+	}
+}
+
+func (v *JSONView) emit(e jsonEvent) {
+	e.Timestamp = v.now()
+	// encoding errors here would mean stdout is broken; there's nothing
+	// useful left to do about it
+	_ = v.enc.Encode(e)
+}
+
+func (v *JSONView) PlanStarted(args []string) {
+	v.emit(jsonEvent{Type: "plan_started", Args: args})
+}
+
+func (v *JSONView) ApplyStarted(args []string) {
+	v.emit(jsonEvent{Type: "apply_started", Args: args})
+}
+
+func (v *JSONView) ChangeIdentified(changeUuid uuid.UUID) {
+	v.emit(jsonEvent{Type: "change_identified", ChangeUUID: &changeUuid})
+}
+
+func (v *JSONView) SnapshotProgress(name string, state string, items uint32, edges uint32) {
+	v.emit(jsonEvent{Type: "snapshot_progress", Name: name, State: state, Items: &items, Edges: &edges})
+}
+
+func (v *JSONView) Diagnostic(err error) {
+	v.emit(jsonEvent{Type: "diagnostic", Error: err.Error()})
+}
+
+func (v *JSONView) Done(err error) {
+	e := jsonEvent{Type: "done"}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	v.emit(e)
+}
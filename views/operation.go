@@ -0,0 +1,35 @@
+// Package views decouples overmind's terraform commands from Bubbletea
+// rendering so that `overmind terraform apply`/`plan` behave sensibly in CI
+// logs, GitHub Actions, and other non-TTY pipes where escape codes and the
+// alt-screen get mangled.
+package views
+
+import "github.com/google/uuid"
+
+// Operation is the event sink for a single `terraform apply` or `terraform
+// plan` run. Commands post events to it as they happen rather than rendering
+// directly, and the concrete implementation decides how (or whether) to
+// display them.
+type Operation interface {
+	// PlanStarted is called once `terraform plan` has been kicked off.
+	PlanStarted(args []string)
+
+	// ApplyStarted is called once `terraform apply` has been kicked off.
+	ApplyStarted(args []string)
+
+	// ChangeIdentified is called once the Overmind change for this run has
+	// been created or resolved.
+	ChangeIdentified(changeUuid uuid.UUID)
+
+	// SnapshotProgress reports progress for a starting/ending change
+	// snapshot. name identifies which snapshot this is (e.g. "starting
+	// change", "ending change").
+	SnapshotProgress(name string, state string, items uint32, edges uint32)
+
+	// Diagnostic surfaces a non-fatal error or warning.
+	Diagnostic(err error)
+
+	// Done is called exactly once the operation has finished, successfully
+	// or not.
+	Done(err error)
+}
@@ -0,0 +1,53 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// PlainView renders Operation events as plain, append-only log lines with no
+// escape codes or cursor movement. It's the right choice for CI logs and
+// anywhere else that can't handle the Bubbletea alt-screen.
+type PlainView struct {
+	out io.Writer
+}
+
+// assert that PlainView implements Operation
+var _ Operation = (*PlainView)(nil)
+
+// NewPlainView returns an Operation that writes human-readable lines to out.
+func NewPlainView(out io.Writer) *PlainView {
+	return &PlainView{out: out}
+}
+
+func (v *PlainView) PlanStarted(args []string) {
+	fmt.Fprintf(v.out, "==> running terraform %v\n", strings.Join(args, " "))
+}
+
+func (v *PlainView) ApplyStarted(args []string) {
+	fmt.Fprintf(v.out, "==> running terraform %v\n", strings.Join(args, " "))
+}
+
+func (v *PlainView) ChangeIdentified(changeUuid uuid.UUID) {
+	fmt.Fprintf(v.out, "==> change: %v\n", changeUuid)
+}
+
+func (v *PlainView) SnapshotProgress(name string, state string, items uint32, edges uint32) {
+	fmt.Fprintf(v.out, "    %v: %v (%d items, %d edges)\n", name, state, items, edges)
+}
+
+func (v *PlainView) Diagnostic(err error) {
+	fmt.Fprintf(v.out, "!!! %v\n", err)
+}
+
+func (v *PlainView) Done(err error) {
+	if err != nil {
+		fmt.Fprintf(v.out, "==> failed: %v\n", err)
+		return
+	}
+
+	fmt.Fprintln(v.out, "==> done")
+}
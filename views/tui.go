@@ -0,0 +1,90 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// TUIView is the default Operation implementation on a terminal: it turns
+// events into Bubbletea messages sent to the bound tea.Program. tfApplyModel
+// does not handle these messages; its spinners and snapshots are rendered
+// from its own state as before. It never writes to stdout directly; the
+// bound tea.Program's own renderer does that.
+type TUIView struct {
+	program *tea.Program
+}
+
+// assert that TUIView implements Operation
+var _ Operation = (*TUIView)(nil)
+
+// NewTUIView returns an Operation that forwards events as tea.Msg once Bind
+// has been called with the running Program. Events posted before Bind is
+// called are dropped, since there is nothing yet to render them.
+func NewTUIView() *TUIView {
+	return &TUIView{}
+}
+
+// Bind attaches the tea.Program that events should be sent to.
+// runOperationModel calls this once the Program has been constructed, before
+// it starts Run-ing the model.
+func (v *TUIView) Bind(program *tea.Program) {
+	v.program = program
+}
+
+// PlanStartedMsg is sent when `terraform plan` starts.
+type PlanStartedMsg struct{ Args []string }
+
+// ApplyStartedMsg is sent when `terraform apply` starts.
+type ApplyStartedMsg struct{ Args []string }
+
+// ChangeIdentifiedMsg is sent once the Overmind change for this run is known.
+type ChangeIdentifiedMsg struct{ ChangeUUID uuid.UUID }
+
+// SnapshotProgressMsg is sent for every starting/ending change snapshot
+// update.
+type SnapshotProgressMsg struct {
+	Name  string
+	State string
+	Items uint32
+	Edges uint32
+}
+
+// DiagnosticMsg is sent for non-fatal errors or warnings.
+type DiagnosticMsg struct{ Err error }
+
+// DoneMsg is sent once the operation has finished, successfully or not.
+type DoneMsg struct{ Err error }
+
+func (v *TUIView) PlanStarted(args []string) {
+	v.send(PlanStartedMsg{Args: args})
+}
+
+func (v *TUIView) ApplyStarted(args []string) {
+	v.send(ApplyStartedMsg{Args: args})
+}
+
+func (v *TUIView) ChangeIdentified(changeUuid uuid.UUID) {
+	v.send(ChangeIdentifiedMsg{ChangeUUID: changeUuid})
+}
+
+func (v *TUIView) SnapshotProgress(name string, state string, items uint32, edges uint32) {
+	v.send(SnapshotProgressMsg{Name: name, State: state, Items: items, Edges: edges})
+}
+
+func (v *TUIView) Diagnostic(err error) {
+	v.send(DiagnosticMsg{Err: err})
+}
+
+func (v *TUIView) Done(err error) {
+	v.send(DoneMsg{Err: err})
+}
+
+func (v *TUIView) send(msg tea.Msg) {
+	if v.program == nil {
+		return
+	}
+	// Events are posted from the model's own Init/Update, which run on the
+	// program's event loop; a synchronous Send there would block forever on
+	// the program's unbuffered message channel.
+	go v.program.Send(msg)
+}